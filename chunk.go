@@ -0,0 +1,176 @@
+package datatable
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// defaultChunkSize is the number of rows read or written at a time by
+// Table implementations, unless overridden.
+const defaultChunkSize = 1024
+
+// Chunk is a fixed-size batch of rows read from or written to a Table.
+type Chunk struct {
+	rows [][]string
+}
+
+// NumRow returns the number of rows in the chunk.
+func (c *Chunk) NumRow() int {
+	return len(c.rows)
+}
+
+// Row returns the row at index x within the chunk.
+func (c *Chunk) Row(x int) []string {
+	return c.rows[x]
+}
+
+// Rows returns all the rows in the chunk.
+func (c *Chunk) Rows() [][]string {
+	return c.rows
+}
+
+// Table is implemented by anything that can be read chunk-at-a-time,
+// so that operations like Join can work on data sets too large to fit
+// in memory. DataTable and StreamTable both implement Table.
+type Table interface {
+	// NumCol returns the number of columns.
+	NumCol() int
+	// NextChunk returns the next chunk of rows, or io.EOF once exhausted.
+	NextChunk() (*Chunk, error)
+	// Reset rewinds the table so the next NextChunk call starts from
+	// the first row again.
+	Reset() error
+}
+
+// NextChunk returns the next defaultChunkSize rows starting from the
+// current chunk cursor, or io.EOF once all rows have been returned.
+func (dt *DataTable) NextChunk() (*Chunk, error) {
+	if dt.chunkPos >= dt.nrow {
+		return nil, io.EOF
+	}
+	end := dt.chunkPos + defaultChunkSize
+	if end > dt.nrow {
+		end = dt.nrow
+	}
+	chunk := &Chunk{rows: dt.rows[dt.chunkPos:end]}
+	dt.chunkPos = end
+	return chunk, nil
+}
+
+// Reset rewinds the chunk cursor used by NextChunk back to the first row.
+func (dt *DataTable) Reset() error {
+	dt.chunkPos = 0
+	return nil
+}
+
+// StreamTable is a Table backed by an io.Reader, for data sets that
+// should not be fully materialized in memory. If the reader was opened
+// from a file path, Reset reopens the file so the stream can be
+// consumed again; otherwise Reset returns an error, since a generic
+// io.Reader cannot be rewound.
+type StreamTable struct {
+	ncol      int
+	chunkSize int
+	reader    *csv.Reader
+	path      string // empty unless backed by a reopenable file
+	open      func(path string) (io.Reader, io.Closer, error)
+	closer    io.Closer
+}
+
+// NewStreamTable wraps r as a Table with the given number of columns,
+// reading defaultChunkSize rows at a time. The returned StreamTable
+// cannot be Reset, since r is a one-shot io.Reader.
+func NewStreamTable(r io.Reader, ncol int) *StreamTable {
+	return &StreamTable{
+		ncol:      ncol,
+		chunkSize: defaultChunkSize,
+		reader:    csv.NewReader(r),
+	}
+}
+
+// NewStreamTableFromFile opens path as a CSV-encoded StreamTable.
+// Unlike NewStreamTable, the returned StreamTable supports Reset by
+// reopening the file.
+func NewStreamTableFromFile(path string, ncol int, open func(path string) (io.Reader, io.Closer, error)) (*StreamTable, error) {
+	st := &StreamTable{
+		ncol:      ncol,
+		chunkSize: defaultChunkSize,
+		path:      path,
+		open:      open,
+	}
+	if err := st.reopen(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func (st *StreamTable) reopen() error {
+	if st.closer != nil {
+		st.closer.Close()
+	}
+	r, closer, err := st.open(st.path)
+	if err != nil {
+		return err
+	}
+	st.reader = csv.NewReader(r)
+	st.closer = closer
+	return nil
+}
+
+// NumCol returns the number of columns.
+func (st *StreamTable) NumCol() int {
+	return st.ncol
+}
+
+// NextChunk reads up to st.chunkSize rows from the underlying reader.
+// A final partial chunk is returned with a nil error; once the underlying
+// reader is exhausted, the next call returns a nil chunk and io.EOF.
+func (st *StreamTable) NextChunk() (*Chunk, error) {
+	rows := make([][]string, 0, st.chunkSize)
+	for len(rows) < st.chunkSize {
+		row, err := st.reader.Read()
+		if err == io.EOF {
+			if len(rows) == 0 {
+				return nil, io.EOF
+			}
+			return &Chunk{rows: rows}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return &Chunk{rows: rows}, nil
+}
+
+// Reset rewinds the stream. It only succeeds if the StreamTable was
+// created with NewStreamTableFromFile.
+func (st *StreamTable) Reset() error {
+	if st.path == "" {
+		return errStreamNotResettable
+	}
+	return st.reopen()
+}
+
+// Close releases any file handle held by the StreamTable.
+func (st *StreamTable) Close() error {
+	if st.closer == nil {
+		return nil
+	}
+	return st.closer.Close()
+}
+
+// WriteChunks drains chunks and writes every row to w as CSV, flushing
+// once the channel is closed.
+func WriteChunks(w io.Writer, chunks <-chan *Chunk) error {
+	writer := csv.NewWriter(w)
+	for chunk := range chunks {
+		for _, row := range chunk.rows {
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
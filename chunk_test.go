@@ -0,0 +1,120 @@
+package datatable
+
+import (
+	"io"
+	"testing"
+)
+
+func Test_DataTable_NextChunk(t *testing.T) {
+	dt := NewDataTable(1)
+	for i := 0; i < defaultChunkSize+10; i++ {
+		dt.AppendRow([]string{"v"})
+	}
+
+	chunk1, err := dt.NextChunk()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chunk1.NumRow() != defaultChunkSize {
+		t.Errorf("expected first chunk to have %d rows, got %d", defaultChunkSize, chunk1.NumRow())
+	}
+
+	chunk2, err := dt.NextChunk()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chunk2.NumRow() != 10 {
+		t.Errorf("expected second chunk to have 10 rows, got %d", chunk2.NumRow())
+	}
+
+	if _, err := dt.NextChunk(); err != io.EOF {
+		t.Errorf("expected io.EOF once exhausted, got %v", err)
+	}
+
+	dt.Reset()
+	if chunk, err := dt.NextChunk(); err != nil || chunk.NumRow() != defaultChunkSize {
+		t.Errorf("expected Reset to rewind the chunk cursor")
+	}
+}
+
+// joinedRowsByKey drains chunks into a map of joined rows keyed by their
+// first column, so tests can assert on cell order rather than just counts.
+func joinedRowsByKey(out <-chan *Chunk) map[string][]string {
+	rows := make(map[string][]string)
+	for c := range out {
+		for _, row := range c.Rows() {
+			rows[row[0]] = row
+		}
+	}
+	return rows
+}
+
+func Test_HashJoinChunks(t *testing.T) {
+	left := NewDataTable(2)
+	left.AppendRow([]string{"1", "a"})
+	left.AppendRow([]string{"2", "b"})
+	left.AppendRow([]string{"3", "c"})
+
+	right := NewDataTable(2)
+	right.AppendRow([]string{"1", "x"})
+	right.AppendRow([]string{"2", "y"})
+
+	keyFn := func(r []string) []string { return []string{r[0]} }
+
+	out := make(chan *Chunk)
+	go func() {
+		if err := HashJoinChunks(left, right, keyFn, keyFn, out); err != nil {
+			t.Error(err)
+		}
+	}()
+	rows := joinedRowsByKey(out)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 joined rows, got %d", len(rows))
+	}
+	// Left columns must come first, matching Join/LeftJoin/HashJoin.
+	want := []string{"1", "a", "1", "x"}
+	if got := rows["1"]; !equalRows(got, want) {
+		t.Errorf("expected joined row %v, got %v", want, got)
+	}
+}
+
+func Test_GraceHashJoin(t *testing.T) {
+	left := NewDataTable(2)
+	left.AppendRow([]string{"1", "a"})
+	left.AppendRow([]string{"2", "b"})
+	left.AppendRow([]string{"3", "c"})
+
+	right := NewDataTable(2)
+	right.AppendRow([]string{"1", "x"})
+	right.AppendRow([]string{"2", "y"})
+
+	keyFn := func(r []string) []string { return []string{r[0]} }
+
+	out := make(chan *Chunk)
+	go func() {
+		if err := GraceHashJoin(left, right, keyFn, keyFn, 4, out); err != nil {
+			t.Error(err)
+		}
+	}()
+	rows := joinedRowsByKey(out)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 joined rows, got %d", len(rows))
+	}
+	// Left columns must come first, matching Join/LeftJoin/HashJoin.
+	want := []string{"1", "a", "1", "x"}
+	if got := rows["1"]; !equalRows(got, want) {
+		t.Errorf("expected joined row %v, got %v", want, got)
+	}
+}
+
+func equalRows(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package datatable
+
+import "sort"
+
+// Query starts a fluent, composable read-side pipeline over dt, built from
+// Where, Select, OrderBy, Skip and Limit clauses and compiled by Cursor.
+// Rows are filtered, ordered, skipped and limited first; Select projects
+// each row only as it is fetched. It replaces having to
+// materialize an intermediate DataTable for each step when all the
+// caller wants is to traverse a filtered, projected view of the rows.
+func (dt *DataTable) Query() *QueryBuilder {
+	return &QueryBuilder{dt: dt}
+}
+
+// QueryBuilder accumulates the clauses of a query before they are
+// compiled into a Cursor.
+type QueryBuilder struct {
+	dt *DataTable
+
+	where func(row []string) bool
+
+	selectCols []int
+
+	hasOrderBy bool
+	orderByCol int
+	orderByAsc bool
+
+	skip  int
+	limit int
+}
+
+// Where restricts the query to rows for which fn returns true.
+func (q *QueryBuilder) Where(fn func(row []string) bool) *QueryBuilder {
+	q.where = fn
+	return q
+}
+
+// Select projects each row onto the given column indexes, in order.
+// Without a Select clause, rows are returned with all their columns.
+func (q *QueryBuilder) Select(colIdxs ...int) *QueryBuilder {
+	q.selectCols = colIdxs
+	return q
+}
+
+// OrderBy sorts the query result by column colIdx, ascending if asc is
+// true and descending otherwise. Ordering happens before Skip and Limit.
+// colIdx is a column of the underlying table, not of the Select result.
+func (q *QueryBuilder) OrderBy(colIdx int, asc bool) *QueryBuilder {
+	q.hasOrderBy = true
+	q.orderByCol = colIdx
+	q.orderByAsc = asc
+	return q
+}
+
+// Skip drops the first n rows of the (filtered, ordered) result.
+func (q *QueryBuilder) Skip(n int) *QueryBuilder {
+	q.skip = n
+	return q
+}
+
+// Limit caps the result at the first n rows remaining after Skip.
+// n == 0 means no limit.
+func (q *QueryBuilder) Limit(n int) *QueryBuilder {
+	q.limit = n
+	return q
+}
+
+// Cursor compiles the accumulated query clauses into a Cursor ready for
+// Fetch.
+func (q *QueryBuilder) Cursor() *Cursor {
+	return &Cursor{
+		dt:         q.dt,
+		where:      q.where,
+		selectCols: q.selectCols,
+		hasOrderBy: q.hasOrderBy,
+		orderByCol: q.orderByCol,
+		orderByAsc: q.orderByAsc,
+		skip:       q.skip,
+		limit:      q.limit,
+	}
+}
+
+// Cursor traverses the rows matched by a query, in order, without
+// materializing an intermediate DataTable. The matching row indexes are
+// computed once, on the first Fetch call, so repeated Fetch calls are
+// O(n) in the number of rows returned.
+type Cursor struct {
+	dt *DataTable
+
+	where      func(row []string) bool
+	selectCols []int
+	hasOrderBy bool
+	orderByCol int
+	orderByAsc bool
+	skip       int
+	limit      int
+
+	index    []int
+	computed bool
+	pos      int
+}
+
+// Fetch returns the next n rows of the cursor, advancing its internal
+// position. n == 0 means all remaining rows. Fetch returns an empty,
+// non-nil slice once the cursor is exhausted.
+func (cur *Cursor) Fetch(n int) ([][]string, error) {
+	if !cur.computed {
+		cur.index = cur.computeIndex()
+		cur.computed = true
+	}
+
+	end := len(cur.index)
+	if n > 0 && cur.pos+n < end {
+		end = cur.pos + n
+	}
+
+	rows := make([][]string, 0, end-cur.pos)
+	for ; cur.pos < end; cur.pos++ {
+		rows = append(rows, cur.project(cur.dt.GetRow(cur.index[cur.pos])))
+	}
+	return rows, nil
+}
+
+// ToDataTable fetches every remaining row and collects it into a fresh
+// DataTable.
+func (cur *Cursor) ToDataTable() (*DataTable, error) {
+	rows, err := cur.Fetch(0)
+	if err != nil {
+		return nil, err
+	}
+	ncol := cur.dt.NumCol()
+	if cur.selectCols != nil {
+		ncol = len(cur.selectCols)
+	}
+	dt2 := NewDataTable(ncol)
+	for _, row := range rows {
+		if err := dt2.AppendRow(row); err != nil {
+			return nil, err
+		}
+	}
+	return dt2, nil
+}
+
+// computeIndex applies Where, OrderBy, Skip and Limit, in that order, to
+// the row indexes of the underlying table.
+func (cur *Cursor) computeIndex() []int {
+	index := make([]int, 0, cur.dt.NumRow())
+	for x := 0; x < cur.dt.NumRow(); x++ {
+		if cur.where == nil || cur.where(cur.dt.GetRow(x)) {
+			index = append(index, x)
+		}
+	}
+
+	if cur.hasOrderBy {
+		sort.SliceStable(index, func(a, b int) bool {
+			va := cur.dt.Get(index[a], cur.orderByCol)
+			vb := cur.dt.Get(index[b], cur.orderByCol)
+			if cur.orderByAsc {
+				return va < vb
+			}
+			return va > vb
+		})
+	}
+
+	if cur.skip > 0 {
+		if cur.skip >= len(index) {
+			index = index[:0]
+		} else {
+			index = index[cur.skip:]
+		}
+	}
+	if cur.limit > 0 && cur.limit < len(index) {
+		index = index[:cur.limit]
+	}
+	return index
+}
+
+// project applies the cursor's Select clause to row, if any.
+func (cur *Cursor) project(row []string) []string {
+	if cur.selectCols == nil {
+		return row
+	}
+	projected := make([]string, len(cur.selectCols))
+	for i, y := range cur.selectCols {
+		projected[i] = row[y]
+	}
+	return projected
+}
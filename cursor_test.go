@@ -0,0 +1,91 @@
+package datatable
+
+import "testing"
+
+func newQueryTestTable() *DataTable {
+	dt := NewDataTable(2)
+	dt.AppendRow([]string{"3", "c"})
+	dt.AppendRow([]string{"1", "a"})
+	dt.AppendRow([]string{"4", "d"})
+	dt.AppendRow([]string{"2", "b"})
+	dt.AppendRow([]string{"5", "e"})
+	return dt
+}
+
+func Test_Cursor_WhereSelectOrderBySkipLimit(t *testing.T) {
+	dt := newQueryTestTable()
+
+	cur := dt.Query().
+		Where(func(row []string) bool { return row[0] != "3" }).
+		OrderBy(0, true).
+		Skip(1).
+		Limit(2).
+		Select(1).
+		Cursor()
+
+	rows, err := cur.Fetch(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// After removing "3c" and ordering by column 0 ascending: 1,2,4,5.
+	// Skip(1) drops "1", Limit(2) keeps "2","4", Select(1) keeps the name column.
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "b" || rows[1][0] != "d" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+}
+
+func Test_Cursor_FetchInBatches(t *testing.T) {
+	dt := newQueryTestTable()
+
+	cur := dt.Query().OrderBy(0, true).Cursor()
+
+	batch1, err := cur.Fetch(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch1) != 2 || batch1[0][0] != "1" || batch1[1][0] != "2" {
+		t.Errorf("unexpected first batch: %v", batch1)
+	}
+
+	batch2, err := cur.Fetch(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch2) != 2 || batch2[0][0] != "3" || batch2[1][0] != "4" {
+		t.Errorf("unexpected second batch: %v", batch2)
+	}
+
+	rest, err := cur.Fetch(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 1 || rest[0][0] != "5" {
+		t.Errorf("unexpected final batch: %v", rest)
+	}
+
+	empty, err := cur.Fetch(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected no more rows, got %v", empty)
+	}
+}
+
+func Test_Cursor_ToDataTable(t *testing.T) {
+	dt := newQueryTestTable()
+
+	dt2, err := dt.Query().Where(func(row []string) bool { return row[0] == "1" || row[0] == "2" }).Cursor().ToDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt2.NumRow() != 2 {
+		t.Errorf("expected 2 rows, got %d", dt2.NumRow())
+	}
+	if dt2.NumCol() != dt.NumCol() {
+		t.Errorf("expected %d columns, got %d", dt.NumCol(), dt2.NumCol())
+	}
+}
@@ -14,6 +14,9 @@ var (
 	errSingleCol  = errors.New("Refuse to remove the last column")
 	// errEmptyCSVFile is when the CSV file to load is empty
 	errEmptyCSVFile = errors.New("CSV file is empty")
+	// errStreamNotResettable is when Reset is called on a StreamTable
+	// that was not opened from a reopenable file.
+	errStreamNotResettable = errors.New("stream table cannot be reset")
 )
 
 // DataTable is an in-memory relational table.
@@ -22,6 +25,10 @@ type DataTable struct {
 	rows [][]string
 	nrow int
 	ncol int
+
+	// chunkPos is the cursor used by NextChunk/Reset to let DataTable
+	// satisfy the Table interface, handing out defaultChunkSize rows at a time.
+	chunkPos int
 }
 
 // NewDataTable creates a new data table with a given number of columns.
@@ -81,12 +88,24 @@ func (dt *DataTable) GetColumn(y int) []string {
 // is the corresponding value.
 // Error is returned immediately if encountered.
 func (dt *DataTable) ApplyColumn(fn func(int, string) error, y int) error {
-	for x, row := range dt.rows {
-		if err := fn(x, row[y]); err != nil {
+	dt.Reset()
+	defer dt.Reset()
+	x := 0
+	for {
+		chunk, err := dt.NextChunk()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
 			return err
 		}
+		for _, row := range chunk.Rows() {
+			if err := fn(x, row[y]); err != nil {
+				return err
+			}
+			x++
+		}
 	}
-	return nil
 }
 
 // ApplyColumns calls the function fn using all values in multiple columns
@@ -95,16 +114,28 @@ func (dt *DataTable) ApplyColumn(fn func(int, string) error, y int) error {
 // is the corresponding row projected on the given columns.
 // Error is returned immediately if encountered.
 func (dt *DataTable) ApplyColumns(fn func(int, []string) error, ys ...int) error {
-	for x, row := range dt.rows {
-		row2 := make([]string, len(ys))
-		for y2, y := range ys {
-			row2[y2] = row[y]
+	dt.Reset()
+	defer dt.Reset()
+	x := 0
+	for {
+		chunk, err := dt.NextChunk()
+		if err == io.EOF {
+			return nil
 		}
-		if err := fn(x, row2); err != nil {
+		if err != nil {
 			return err
 		}
+		for _, row := range chunk.Rows() {
+			row2 := make([]string, len(ys))
+			for y2, y := range ys {
+				row2[y2] = row[y]
+			}
+			if err := fn(x, row2); err != nil {
+				return err
+			}
+			x++
+		}
 	}
-	return nil
 }
 
 // RemoveColumn deletes the column at index y
@@ -254,6 +285,11 @@ func FromCSV(file *csv.Reader) (*DataTable, error) {
 func Join(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
 	out := make(chan []string)
 	go func() {
+		defer close(out)
+		// Indexed instead of chunk-at-a-time: left and right may be the
+		// same *DataTable (a self-join), and both sides share a single
+		// chunkPos cursor, so driving iteration with Reset/NextChunk
+		// would have the inner loop's Reset rewind the outer loop too.
 		for i := 0; i < left.NumRow(); i++ {
 			l := left.GetRow(i)
 			for j := 0; j < right.NumRow(); j++ {
@@ -263,7 +299,6 @@ func Join(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
 				}
 			}
 		}
-		close(out)
 	}()
 	joined := NewDataTable(left.NumCol() + right.NumCol())
 	for row := range out {
@@ -280,6 +315,8 @@ func Join(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
 func LeftJoin(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
 	out := make(chan []string)
 	go func() {
+		defer close(out)
+		// Indexed instead of chunk-at-a-time: see the comment in Join.
 		for i := 0; i < left.NumRow(); i++ {
 			l := left.GetRow(i)
 			var rowsJoined int
@@ -295,7 +332,6 @@ func LeftJoin(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
 				out <- append(l, r...)
 			}
 		}
-		close(out)
 	}()
 	joined := NewDataTable(left.NumCol() + right.NumCol())
 	for row := range out {
@@ -307,50 +343,39 @@ func LeftJoin(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
 // HashJoin performs equal join on the two tables, and returns the result as
 // a new DataTable.
 // fnLeft and fnRight are functions that take a row as the input and return
-// the value used for equality condition in join.
+// the composite key used for the equality condition in the join, so that
+// joining on multiple columns doesn't require the caller to concatenate
+// them with a fragile separator.
 // HashJoin is generally faster than Join, which does nested loop join, but uses more
 // memory due to the temporary hash table.
-func HashJoin(left, right *DataTable, fnLeft, fnRight func([]string) string) *DataTable {
+func HashJoin(left, right *DataTable, fnLeft, fnRight func([]string) []string) *DataTable {
 	out := make(chan []string)
 	go func() {
 		defer close(out)
 		// Find relative sizes
 		var smaller, larger *DataTable
-		var fnSmall, fnLarge func([]string) string
+		var fnSmall, fnLarge func([]string) []string
 		var fnJoin func([]string, []string) []string
 		if left.NumRow() > right.NumRow() {
 			smaller, larger = right, left
 			fnSmall, fnLarge = fnRight, fnLeft
 			fnJoin = func(s, l []string) []string {
-				return append(l, s...)
+				return append(append([]string{}, l...), s...)
 			}
 		} else {
 			smaller, larger = left, right
 			fnSmall, fnLarge = fnLeft, fnRight
 			fnJoin = func(s, l []string) []string {
-				return append(s, l...)
-			}
-		}
-		// Build map for the larger
-		ht := make(map[string][][]string)
-		for i := 0; i < larger.NumRow(); i++ {
-			row := larger.GetRow(i)
-			key := fnLarge(row)
-			if _, exists := ht[key]; !exists {
-				ht[key] = make([][]string, 0)
+				return append(append([]string{}, s...), l...)
 			}
-			ht[key] = append(ht[key], row)
 		}
-		// Perform join
-		for i := 0; i < smaller.NumRow(); i++ {
-			rowSmall := smaller.GetRow(i)
-			key := fnSmall(rowSmall)
-			if bucket, exists := ht[key]; exists {
-				for _, rowLarge := range bucket {
-					out <- fnJoin(rowSmall, rowLarge)
-				}
-			}
+		// Build the hash table on the larger side, chunk-at-a-time.
+		ht, err := buildHashTable(larger, fnLarge)
+		if err != nil {
+			return
 		}
+		// Stream the smaller side chunk-at-a-time and probe.
+		probeHashTable(smaller, fnSmall, ht, fnJoin, out)
 	}()
 	joined := NewDataTable(left.NumCol() + right.NumCol())
 	for row := range out {
@@ -26,7 +26,7 @@ func Test_Create(t *testing.T) {
 	if dt.Get(0, 2) != "c" {
 		t.Fail()
 	}
-	if err := dt.AppendRow([]string{"1", "2"}); err != NumColError {
+	if err := dt.AppendRow([]string{"1", "2"}); err != errNumCol {
 		t.Error(err)
 	}
 	if row := dt.GetRow(1); row[0] != "e" {
@@ -183,6 +183,36 @@ func Test_Join(t *testing.T) {
 	}
 }
 
+func Test_Join_SelfJoinMultiChunk(t *testing.T) {
+	// Join drives both sides through the same *DataTable when left and
+	// right alias each other, so it must not iterate either side via the
+	// shared chunkPos cursor: once left spans more than one chunk, the
+	// inner loop's Reset on the same table would rewind the outer loop.
+	n := defaultChunkSize + 5
+	dt := NewDataTable(1)
+	for i := 0; i < n; i++ {
+		dt.AppendRow([]string{"k"})
+	}
+
+	joined := Join(dt, dt, func(l, r []string) bool { return true })
+	if got, want := joined.NumRow(), n*n; got != want {
+		t.Errorf("expected %d self-joined rows, got %d", want, got)
+	}
+}
+
+func Test_LeftJoin_SelfJoinMultiChunk(t *testing.T) {
+	n := defaultChunkSize + 5
+	dt := NewDataTable(1)
+	for i := 0; i < n; i++ {
+		dt.AppendRow([]string{"k"})
+	}
+
+	joined := LeftJoin(dt, dt, func(l, r []string) bool { return true })
+	if got, want := joined.NumRow(), n*n; got != want {
+		t.Errorf("expected %d self-joined rows, got %d", want, got)
+	}
+}
+
 func Test_HashJoin(t *testing.T) {
 	dt := NewDataTable(3)
 	dt.AppendRow([]string{"a", "b", "c"})
@@ -196,10 +226,10 @@ func Test_HashJoin(t *testing.T) {
 	dt2.AppendRow([]string{"k", "3"})
 
 	dt3 := HashJoin(dt, dt2,
-		func(l []string) string {
-			return l[0]
-		}, func(r []string) string {
-			return r[0]
+		func(l []string) []string {
+			return []string{l[0]}
+		}, func(r []string) []string {
+			return []string{r[0]}
 		})
 	if n := dt3.NumCol(); n != 5 {
 		t.Error(n)
@@ -0,0 +1,51 @@
+package datatable
+
+// Index is a reusable row-position index over a DataTable, keyed by a
+// caller-supplied key function. Unlike the hash table HashJoin builds
+// and discards for a single join, an Index is meant to be built once and
+// reused across many probe queries against the same table.
+type Index struct {
+	table     *DataTable
+	positions map[string][]int
+}
+
+// BuildIndex materializes an Index over dt, keyed by keyFn(row).
+// The index stores row positions, not copies of the rows, so it stays
+// small relative to the table it indexes.
+func (dt *DataTable) BuildIndex(keyFn func(row []string) string) *Index {
+	positions := make(map[string][]int)
+	for x := 0; x < dt.NumRow(); x++ {
+		key := keyFn(dt.GetRow(x))
+		positions[key] = append(positions[key], x)
+	}
+	return &Index{table: dt, positions: positions}
+}
+
+// LookupRows returns the row positions in the indexed table matching key.
+func (idx *Index) LookupRows(key string) []int {
+	return idx.positions[key]
+}
+
+// Lookup returns the rows in the indexed table matching key.
+func (idx *Index) Lookup(key string) [][]string {
+	positions := idx.positions[key]
+	rows := make([][]string, len(positions))
+	for i, x := range positions {
+		rows[i] = idx.table.GetRow(x)
+	}
+	return rows
+}
+
+// IndexLookupJoin is like HashJoin, except that it probes a pre-built
+// Index on the right table instead of hashing it fresh, amortizing the
+// hash build across repeated joins against the same right table.
+func IndexLookupJoin(left *DataTable, rightIdx *Index, keyFn func(row []string) string) *DataTable {
+	joined := NewDataTable(left.NumCol() + rightIdx.table.NumCol())
+	for i := 0; i < left.NumRow(); i++ {
+		l := left.GetRow(i)
+		for _, r := range rightIdx.Lookup(keyFn(l)) {
+			joined.AppendRow(append(append([]string{}, l...), r...))
+		}
+	}
+	return joined
+}
@@ -0,0 +1,32 @@
+package datatable
+
+import "testing"
+
+func Test_Index_LookupAndIndexLookupJoin(t *testing.T) {
+	dim := NewDataTable(2)
+	dim.AppendRow([]string{"1", "alice"})
+	dim.AppendRow([]string{"2", "bob"})
+	dim.AppendRow([]string{"1", "alicia"})
+
+	idx := dim.BuildIndex(func(row []string) string { return row[0] })
+
+	if rows := idx.Lookup("1"); len(rows) != 2 {
+		t.Fatalf("expected 2 rows for key 1, got %d", len(rows))
+	}
+	if positions := idx.LookupRows("2"); len(positions) != 1 || positions[0] != 1 {
+		t.Errorf("expected row position [1] for key 2, got %v", positions)
+	}
+	if rows := idx.Lookup("missing"); len(rows) != 0 {
+		t.Errorf("expected no rows for missing key, got %v", rows)
+	}
+
+	fact := NewDataTable(2)
+	fact.AppendRow([]string{"order-1", "1"})
+	fact.AppendRow([]string{"order-2", "2"})
+	fact.AppendRow([]string{"order-3", "9"})
+
+	joined := IndexLookupJoin(fact, idx, func(row []string) string { return row[1] })
+	if n := joined.NumRow(); n != 3 {
+		t.Fatalf("expected 3 joined rows (order-1 x2, order-2 x1), got %d", n)
+	}
+}
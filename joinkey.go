@@ -0,0 +1,21 @@
+package datatable
+
+import (
+	"strconv"
+	"strings"
+)
+
+// encodeKey canonically encodes a composite join key so that it can be
+// used as a Go map key. Each part is length-prefixed, so that e.g.
+// ["a", "bc"] and ["ab", "c"] never collide, which a plain
+// strings.Join with a fixed separator would risk if a value itself
+// contains the separator.
+func encodeKey(parts []string) string {
+	var sb strings.Builder
+	for _, p := range parts {
+		sb.WriteString(strconv.Itoa(len(p)))
+		sb.WriteByte(':')
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package datatable
+
+// RightJoin is similar to Join, except that every row from the right
+// table will be part of the join result even if it doesn't join with
+// any row from the left table.
+// e.g., [empty fields ... right table fields]
+// where the empty fields have the same number of columns as the left table.
+func RightJoin(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
+	out := make(chan []string)
+	go func() {
+		defer close(out)
+		// Indexed instead of chunk-at-a-time: left and right may be the
+		// same *DataTable (a self-join), and both sides share a single
+		// chunkPos cursor, so driving iteration with Reset/NextChunk
+		// would have the inner loop's Reset rewind the outer loop too.
+		for j := 0; j < right.NumRow(); j++ {
+			r := right.GetRow(j)
+			var rowsJoined int
+			for i := 0; i < left.NumRow(); i++ {
+				l := left.GetRow(i)
+				if fn(l, r) {
+					out <- append(l, r...)
+					rowsJoined++
+				}
+			}
+			if rowsJoined == 0 {
+				l := make([]string, left.NumCol())
+				out <- append(l, r...)
+			}
+		}
+	}()
+	joined := NewDataTable(left.NumCol() + right.NumCol())
+	for row := range out {
+		joined.AppendRow(row)
+	}
+	return joined
+}
+
+// FullJoin is similar to Join, except that every row from either table
+// will be part of the join result even if it doesn't join with any row
+// from the other table, padded with empty fields on the side that has
+// no match.
+func FullJoin(left, right *DataTable, fn func(l, r []string) bool) *DataTable {
+	matchedRight := make([]bool, right.NumRow())
+
+	out := make(chan []string)
+	go func() {
+		defer close(out)
+		for i := 0; i < left.NumRow(); i++ {
+			l := left.GetRow(i)
+			var rowsJoined int
+			for j := 0; j < right.NumRow(); j++ {
+				r := right.GetRow(j)
+				if fn(l, r) {
+					out <- append(l, r...)
+					rowsJoined++
+					matchedRight[j] = true
+				}
+			}
+			if rowsJoined == 0 {
+				r := make([]string, right.NumCol())
+				out <- append(l, r...)
+			}
+		}
+		for j, matched := range matchedRight {
+			if matched {
+				continue
+			}
+			l := make([]string, left.NumCol())
+			out <- append(l, right.GetRow(j)...)
+		}
+	}()
+	joined := NewDataTable(left.NumCol() + right.NumCol())
+	for row := range out {
+		joined.AppendRow(row)
+	}
+	return joined
+}
+
+// FullHashJoin performs a hash-based full outer join between left and
+// right, and returns the result as a new DataTable.
+// fnLeft and fnRight are functions that take a row as the input and
+// return the composite key used for the equality condition in the join.
+//
+// Following TiDB's approach, the hash table is built once on the right
+// table, tracked by a matched []bool slice indexed by its row position.
+// The left table is then probed a single time: every match marks the
+// corresponding right row as matched and is emitted immediately, and
+// every left row with no match is emitted padded with empty fields on the
+// right side. once probing finishes, any right row left unmatched is
+// emitted padded with empty fields on the left side.
+func FullHashJoin(left, right *DataTable, fnLeft, fnRight func([]string) []string) *DataTable {
+	ht := make(map[string][]int)
+	for j := 0; j < right.NumRow(); j++ {
+		key := encodeKey(fnRight(right.GetRow(j)))
+		ht[key] = append(ht[key], j)
+	}
+	matched := make([]bool, right.NumRow())
+
+	joined := NewDataTable(left.NumCol() + right.NumCol())
+	for i := 0; i < left.NumRow(); i++ {
+		l := left.GetRow(i)
+		key := encodeKey(fnLeft(l))
+		bucket, exists := ht[key]
+		if !exists {
+			r := make([]string, right.NumCol())
+			joined.AppendRow(append(append([]string{}, l...), r...))
+			continue
+		}
+		for _, j := range bucket {
+			matched[j] = true
+			joined.AppendRow(append(append([]string{}, l...), right.GetRow(j)...))
+		}
+	}
+	for j, ok := range matched {
+		if ok {
+			continue
+		}
+		l := make([]string, left.NumCol())
+		joined.AppendRow(append(l, right.GetRow(j)...))
+	}
+	return joined
+}
@@ -0,0 +1,126 @@
+package datatable
+
+import "testing"
+
+func Test_RightJoin(t *testing.T) {
+	dt := NewDataTable(2)
+	dt.AppendRow([]string{"a", "1"})
+	dt.AppendRow([]string{"b", "2"})
+
+	dt2 := NewDataTable(2)
+	dt2.AppendRow([]string{"a", "x"})
+	dt2.AppendRow([]string{"c", "y"})
+
+	dt3 := RightJoin(dt, dt2, func(l, r []string) bool {
+		return l[0] == r[0]
+	})
+	if n := dt3.NumRow(); n != 2 {
+		t.Fatalf("expected 2 rows, got %d", n)
+	}
+	if row := dt3.GetRow(0); row[0] != "a" || row[2] != "a" {
+		t.Errorf("expected matched row to join a with a, got %v", row)
+	}
+	if row := dt3.GetRow(1); row[0] != "" || row[2] != "c" {
+		t.Errorf("expected unmatched right row c to be padded on the left, got %v", row)
+	}
+}
+
+func Test_RightJoin_SelfJoinMultiChunk(t *testing.T) {
+	// Same aliasing hazard as Join: left and right may be the same
+	// *DataTable, so RightJoin must not drive either side through the
+	// shared chunkPos cursor once it spans more than one chunk.
+	n := defaultChunkSize + 5
+	dt := NewDataTable(1)
+	for i := 0; i < n; i++ {
+		dt.AppendRow([]string{"k"})
+	}
+
+	joined := RightJoin(dt, dt, func(l, r []string) bool { return true })
+	if got, want := joined.NumRow(), n*n; got != want {
+		t.Errorf("expected %d self-joined rows, got %d", want, got)
+	}
+}
+
+func Test_FullJoin(t *testing.T) {
+	dt := NewDataTable(2)
+	dt.AppendRow([]string{"a", "1"})
+	dt.AppendRow([]string{"b", "2"})
+
+	dt2 := NewDataTable(2)
+	dt2.AppendRow([]string{"a", "x"})
+	dt2.AppendRow([]string{"c", "y"})
+
+	dt3 := FullJoin(dt, dt2, func(l, r []string) bool {
+		return l[0] == r[0]
+	})
+	if n := dt3.NumRow(); n != 3 {
+		t.Fatalf("expected 3 rows (matched a, unmatched b, unmatched c), got %d", n)
+	}
+
+	var sawUnmatchedLeft, sawUnmatchedRight bool
+	for i := 0; i < dt3.NumRow(); i++ {
+		row := dt3.GetRow(i)
+		if row[0] == "b" && row[2] == "" {
+			sawUnmatchedLeft = true
+		}
+		if row[0] == "" && row[2] == "c" {
+			sawUnmatchedRight = true
+		}
+	}
+	if !sawUnmatchedLeft {
+		t.Error("expected unmatched left row b to be padded on the right")
+	}
+	if !sawUnmatchedRight {
+		t.Error("expected unmatched right row c to be padded on the left")
+	}
+}
+
+func Test_FullHashJoin(t *testing.T) {
+	dt := NewDataTable(2)
+	dt.AppendRow([]string{"a", "1"})
+	dt.AppendRow([]string{"b", "2"})
+
+	dt2 := NewDataTable(2)
+	dt2.AppendRow([]string{"a", "x"})
+	dt2.AppendRow([]string{"c", "y"})
+
+	keyFn := func(r []string) []string { return []string{r[0]} }
+	dt3 := FullHashJoin(dt, dt2, keyFn, keyFn)
+	if n := dt3.NumRow(); n != 3 {
+		t.Fatalf("expected 3 rows, got %d", n)
+	}
+
+	var sawUnmatchedLeft, sawUnmatchedRight bool
+	for i := 0; i < dt3.NumRow(); i++ {
+		row := dt3.GetRow(i)
+		if row[0] == "b" && row[2] == "" {
+			sawUnmatchedLeft = true
+		}
+		if row[0] == "" && row[2] == "c" {
+			sawUnmatchedRight = true
+		}
+	}
+	if !sawUnmatchedLeft {
+		t.Error("expected unmatched left row b to be padded on the right")
+	}
+	if !sawUnmatchedRight {
+		t.Error("expected unmatched right row c to be padded on the left")
+	}
+}
+
+func Test_HashJoin_CompositeKey(t *testing.T) {
+	dt := NewDataTable(3)
+	dt.AppendRow([]string{"a", "1", "x"})
+	dt.AppendRow([]string{"a", "2", "y"})
+
+	dt2 := NewDataTable(3)
+	dt2.AppendRow([]string{"a", "1", "p"})
+	dt2.AppendRow([]string{"a", "3", "q"})
+
+	dt3 := HashJoin(dt, dt2,
+		func(l []string) []string { return []string{l[0], l[1]} },
+		func(r []string) []string { return []string{r[0], r[1]} })
+	if n := dt3.NumRow(); n != 1 {
+		t.Fatalf("expected 1 row matching on both columns, got %d", n)
+	}
+}
@@ -0,0 +1,265 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ColumnType is the declared type of a Column in a Schema.
+type ColumnType int
+
+const (
+	// StringColumn is the default type: the cell value is used as-is.
+	StringColumn ColumnType = iota
+	// IntColumn cells parse as a base-10 int64.
+	IntColumn
+	// FloatColumn cells parse as a float64.
+	FloatColumn
+	// BoolColumn cells parse with strconv.ParseBool.
+	BoolColumn
+	// TimeColumn cells parse with time.RFC3339.
+	TimeColumn
+)
+
+// Column declares the name and type of a single column in a Schema.
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// RelationKind describes the cardinality of a Relation.
+type RelationKind int
+
+const (
+	// OneToOne means each key on the from side matches at most one row
+	// on the to side, and vice versa.
+	OneToOne RelationKind = iota
+	// OneToMany means each key on the from side may match many rows on
+	// the to side.
+	OneToMany
+	// ManyToMany means keys on either side may match many rows on the
+	// other.
+	ManyToMany
+)
+
+// Relation declares a join between a Schema's table and another table,
+// so that callers can join by name instead of hand-writing key
+// functions every time. ToTable is optional: if set, JoinRelation
+// requires it to be the table passed in, guarding against a caller
+// joining against the wrong table under the relation's name. Kind is
+// enforced only for OneToOne, where JoinRelation rejects a to-side with
+// duplicate ToCol values.
+type Relation struct {
+	Name    string
+	FromCol int
+	ToTable *DataTable
+	ToCol   int
+	Kind    RelationKind
+}
+
+// Schema declares the column types, primary column, and relationships of
+// a DataTable, turning it from a raw string grid into something that can
+// back small relational applications.
+type Schema struct {
+	Columns    []Column
+	PrimaryCol int
+	Relations  []Relation
+}
+
+// relation looks up a Relation by name, or nil if none matches.
+func (s *Schema) relation(name string) *Relation {
+	for i := range s.Relations {
+		if s.Relations[i].Name == name {
+			return &s.Relations[i]
+		}
+	}
+	return nil
+}
+
+var (
+	// errUnknownRelation is when JoinRelation is called with a name that
+	// is not declared in the Schema.
+	errUnknownRelation = errors.New("datatable: unknown relation")
+	// errColumnTypeMismatch is when a typed accessor is called on a
+	// column that was not declared with that type.
+	errColumnTypeMismatch = errors.New("datatable: column type mismatch")
+	// errRelationTargetMismatch is when JoinRelation is called with a
+	// table other than the Relation's declared ToTable.
+	errRelationTargetMismatch = errors.New("datatable: relation target mismatch")
+	// errAmbiguousOneToOne is when a Relation declared OneToOne finds a
+	// ToCol value repeated on the to side, whether or not any FromCol
+	// value matches it.
+	errAmbiguousOneToOne = errors.New("datatable: ambiguous one-to-one relation")
+)
+
+// SchemaTable pairs a DataTable with the Schema that describes it,
+// adding typed accessors and named relationship joins on top of the
+// plain, untyped DataTable API.
+type SchemaTable struct {
+	*DataTable
+	Schema *Schema
+
+	// cache holds, per column, a parsed representation computed once by
+	// CacheColumn for hot paths that repeatedly read the same column.
+	cache map[int]interface{}
+}
+
+// NewDataTableWithSchema creates an empty SchemaTable with the given
+// schema. The underlying DataTable has one column per schema.Columns
+// entry.
+func NewDataTableWithSchema(schema *Schema) *SchemaTable {
+	return &SchemaTable{
+		DataTable: NewDataTable(len(schema.Columns)),
+		Schema:    schema,
+	}
+}
+
+// CacheColumn parses every cell in column y according to its declared
+// type and caches the result, so that later typed accessors on y don't
+// reparse the same cell on every call. Rows appended after CacheColumn
+// runs are not cached: the typed accessors fall back to parsing on the
+// fly for any row index beyond the cached slice.
+func (st *SchemaTable) CacheColumn(y int) error {
+	col := st.Schema.Columns[y]
+	switch col.Type {
+	case IntColumn:
+		values := make([]int64, st.NumRow())
+		for x := range values {
+			v, err := strconv.ParseInt(st.Get(x, y), 10, 64)
+			if err != nil {
+				return err
+			}
+			values[x] = v
+		}
+		st.setCache(y, values)
+	case FloatColumn:
+		values := make([]float64, st.NumRow())
+		for x := range values {
+			v, err := strconv.ParseFloat(st.Get(x, y), 64)
+			if err != nil {
+				return err
+			}
+			values[x] = v
+		}
+		st.setCache(y, values)
+	case BoolColumn:
+		values := make([]bool, st.NumRow())
+		for x := range values {
+			v, err := strconv.ParseBool(st.Get(x, y))
+			if err != nil {
+				return err
+			}
+			values[x] = v
+		}
+		st.setCache(y, values)
+	case TimeColumn:
+		values := make([]time.Time, st.NumRow())
+		for x := range values {
+			v, err := time.Parse(time.RFC3339, st.Get(x, y))
+			if err != nil {
+				return err
+			}
+			values[x] = v
+		}
+		st.setCache(y, values)
+	}
+	return nil
+}
+
+func (st *SchemaTable) setCache(y int, values interface{}) {
+	if st.cache == nil {
+		st.cache = make(map[int]interface{})
+	}
+	st.cache[y] = values
+}
+
+// GetInt parses the cell at row x, column y as an int64. Column y must
+// be declared IntColumn.
+func (st *SchemaTable) GetInt(x, y int) (int64, error) {
+	if st.Schema.Columns[y].Type != IntColumn {
+		return 0, errColumnTypeMismatch
+	}
+	if cached, ok := st.cache[y].([]int64); ok && x < len(cached) {
+		return cached[x], nil
+	}
+	return strconv.ParseInt(st.Get(x, y), 10, 64)
+}
+
+// GetFloat parses the cell at row x, column y as a float64. Column y
+// must be declared FloatColumn.
+func (st *SchemaTable) GetFloat(x, y int) (float64, error) {
+	if st.Schema.Columns[y].Type != FloatColumn {
+		return 0, errColumnTypeMismatch
+	}
+	if cached, ok := st.cache[y].([]float64); ok && x < len(cached) {
+		return cached[x], nil
+	}
+	return strconv.ParseFloat(st.Get(x, y), 64)
+}
+
+// GetBool parses the cell at row x, column y as a bool. Column y must be
+// declared BoolColumn.
+func (st *SchemaTable) GetBool(x, y int) (bool, error) {
+	if st.Schema.Columns[y].Type != BoolColumn {
+		return false, errColumnTypeMismatch
+	}
+	if cached, ok := st.cache[y].([]bool); ok && x < len(cached) {
+		return cached[x], nil
+	}
+	return strconv.ParseBool(st.Get(x, y))
+}
+
+// GetTime parses the cell at row x, column y as a time.Time in RFC3339
+// format. Column y must be declared TimeColumn.
+func (st *SchemaTable) GetTime(x, y int) (time.Time, error) {
+	if st.Schema.Columns[y].Type != TimeColumn {
+		return time.Time{}, errColumnTypeMismatch
+	}
+	if cached, ok := st.cache[y].([]time.Time); ok && x < len(cached) {
+		return cached[x], nil
+	}
+	return time.Parse(time.RFC3339, st.Get(x, y))
+}
+
+// JoinRelation joins st with other using the Relation declared under
+// relName in st's Schema, instead of requiring the caller to hand-write
+// key functions.
+//
+// If the Relation declares a ToTable, other must be that same table. If
+// the Relation is declared OneToOne, JoinRelation also verifies that no
+// ToCol value repeats in other, since a repeat would let a row on st's
+// side join to more than one row on the other side.
+func (st *SchemaTable) JoinRelation(other *DataTable, relName string) (*DataTable, error) {
+	rel := st.Schema.relation(relName)
+	if rel == nil {
+		return nil, fmt.Errorf("%w: %q", errUnknownRelation, relName)
+	}
+	if rel.ToTable != nil && other != rel.ToTable {
+		return nil, fmt.Errorf("%w: %q", errRelationTargetMismatch, relName)
+	}
+	if rel.Kind == OneToOne {
+		if err := checkOneToOne(other, rel.ToCol); err != nil {
+			return nil, fmt.Errorf("%w: %q", err, relName)
+		}
+	}
+	return HashJoin(st.DataTable, other,
+		func(row []string) []string { return []string{row[rel.FromCol]} },
+		func(row []string) []string { return []string{row[rel.ToCol]} },
+	), nil
+}
+
+// checkOneToOne returns errAmbiguousOneToOne if any value in column col
+// of dt repeats.
+func checkOneToOne(dt *DataTable, col int) error {
+	seen := make(map[string]bool, dt.NumRow())
+	for r := 0; r < dt.NumRow(); r++ {
+		v := dt.Get(r, col)
+		if seen[v] {
+			return errAmbiguousOneToOne
+		}
+		seen[v] = true
+	}
+	return nil
+}
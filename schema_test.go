@@ -0,0 +1,142 @@
+package datatable
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_SchemaTable_TypedAccessors(t *testing.T) {
+	schema := &Schema{
+		Columns: []Column{
+			{Name: "id", Type: IntColumn},
+			{Name: "price", Type: FloatColumn},
+			{Name: "active", Type: BoolColumn},
+			{Name: "created", Type: TimeColumn},
+		},
+		PrimaryCol: 0,
+	}
+	st := NewDataTableWithSchema(schema)
+	if err := st.AppendRow([]string{"1", "9.99", "true", "2024-01-02T15:04:05Z"}); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := st.GetInt(0, 0)
+	if err != nil || id != 1 {
+		t.Errorf("GetInt: got (%d, %v), want (1, nil)", id, err)
+	}
+	price, err := st.GetFloat(0, 1)
+	if err != nil || price != 9.99 {
+		t.Errorf("GetFloat: got (%v, %v), want (9.99, nil)", price, err)
+	}
+	active, err := st.GetBool(0, 2)
+	if err != nil || !active {
+		t.Errorf("GetBool: got (%v, %v), want (true, nil)", active, err)
+	}
+	created, err := st.GetTime(0, 3)
+	if err != nil || created.Year() != 2024 {
+		t.Errorf("GetTime: got (%v, %v), want year 2024", created, err)
+	}
+
+	if _, err := st.GetInt(0, 1); err != errColumnTypeMismatch {
+		t.Errorf("expected errColumnTypeMismatch reading a float column as int, got %v", err)
+	}
+}
+
+func Test_SchemaTable_CacheColumn(t *testing.T) {
+	schema := &Schema{Columns: []Column{{Name: "id", Type: IntColumn}}}
+	st := NewDataTableWithSchema(schema)
+	st.AppendRow([]string{"1"})
+	st.AppendRow([]string{"2"})
+
+	if err := st.CacheColumn(0); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := st.GetInt(1, 0); err != nil || v != 2 {
+		t.Errorf("expected cached GetInt to return 2, got (%d, %v)", v, err)
+	}
+}
+
+func Test_SchemaTable_CacheColumn_AppendAfterCache(t *testing.T) {
+	schema := &Schema{Columns: []Column{{Name: "id", Type: IntColumn}}}
+	st := NewDataTableWithSchema(schema)
+	st.AppendRow([]string{"1"})
+
+	if err := st.CacheColumn(0); err != nil {
+		t.Fatal(err)
+	}
+	st.AppendRow([]string{"2"})
+
+	if v, err := st.GetInt(1, 0); err != nil || v != 2 {
+		t.Errorf("expected GetInt to fall back to parsing the uncached row, got (%d, %v)", v, err)
+	}
+}
+
+func Test_SchemaTable_JoinRelation(t *testing.T) {
+	schema := &Schema{
+		Columns: []Column{{Name: "id"}, {Name: "customer_id"}},
+		Relations: []Relation{
+			{Name: "customer", FromCol: 1, ToCol: 0, Kind: OneToMany},
+		},
+	}
+	orders := NewDataTableWithSchema(schema)
+	orders.AppendRow([]string{"order-1", "c1"})
+	orders.AppendRow([]string{"order-2", "c2"})
+
+	customers := NewDataTable(2)
+	customers.AppendRow([]string{"c1", "alice"})
+
+	joined, err := orders.JoinRelation(customers, "customer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := joined.NumRow(); n != 1 {
+		t.Fatalf("expected 1 joined row, got %d", n)
+	}
+
+	if _, err := orders.JoinRelation(customers, "missing"); err == nil {
+		t.Error("expected an error for an undeclared relation")
+	}
+}
+
+func Test_SchemaTable_JoinRelation_ToTableMismatch(t *testing.T) {
+	customers := NewDataTable(2)
+	customers.AppendRow([]string{"c1", "alice"})
+
+	schema := &Schema{
+		Columns: []Column{{Name: "id"}, {Name: "customer_id"}},
+		Relations: []Relation{
+			{Name: "customer", FromCol: 1, ToTable: customers, ToCol: 0, Kind: OneToMany},
+		},
+	}
+	orders := NewDataTableWithSchema(schema)
+	orders.AppendRow([]string{"order-1", "c1"})
+
+	other := NewDataTable(2)
+	other.AppendRow([]string{"c1", "bob"})
+
+	if _, err := orders.JoinRelation(other, "customer"); !errors.Is(err, errRelationTargetMismatch) {
+		t.Errorf("expected errRelationTargetMismatch, got %v", err)
+	}
+	if _, err := orders.JoinRelation(customers, "customer"); err != nil {
+		t.Errorf("expected the declared ToTable to be accepted, got %v", err)
+	}
+}
+
+func Test_SchemaTable_JoinRelation_OneToOneAmbiguous(t *testing.T) {
+	schema := &Schema{
+		Columns: []Column{{Name: "id"}, {Name: "profile_id"}},
+		Relations: []Relation{
+			{Name: "profile", FromCol: 1, ToCol: 0, Kind: OneToOne},
+		},
+	}
+	users := NewDataTableWithSchema(schema)
+	users.AppendRow([]string{"u1", "p1"})
+
+	profiles := NewDataTable(2)
+	profiles.AppendRow([]string{"p1", "first"})
+	profiles.AppendRow([]string{"p1", "duplicate"})
+
+	if _, err := users.JoinRelation(profiles, "profile"); !errors.Is(err, errAmbiguousOneToOne) {
+		t.Errorf("expected errAmbiguousOneToOne, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package datatable
+
+import (
+	"encoding/csv"
+	"hash/fnv"
+	"io"
+	"os"
+)
+
+// buildHashTable reads every chunk of t and groups its rows by fn(row),
+// so that it fits the build side of a hash join. The whole result is
+// held in memory, so t must be small enough to build from (or itself be
+// a single pre-partitioned slice of a larger table, see GraceHashJoin).
+func buildHashTable(t Table, fn func([]string) []string) (map[string][][]string, error) {
+	ht := make(map[string][][]string)
+	if err := t.Reset(); err != nil {
+		return nil, err
+	}
+	for {
+		chunk, err := t.NextChunk()
+		if err == io.EOF {
+			return ht, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range chunk.Rows() {
+			key := encodeKey(fn(row))
+			ht[key] = append(ht[key], row)
+		}
+	}
+}
+
+// probeHashTable streams t chunk-at-a-time, looking up fn(row) in ht and
+// sending join(row, buildRow) for every match to out.
+func probeHashTable(t Table, fn func([]string) []string, ht map[string][][]string, join func(probeRow, buildRow []string) []string, out chan<- []string) error {
+	if err := t.Reset(); err != nil {
+		return err
+	}
+	for {
+		chunk, err := t.NextChunk()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, row := range chunk.Rows() {
+			key := encodeKey(fn(row))
+			if bucket, exists := ht[key]; exists {
+				for _, buildRow := range bucket {
+					out <- join(row, buildRow)
+				}
+			}
+		}
+	}
+}
+
+// HashJoinChunks is like HashJoin, but works over any Table (not just an
+// in-memory DataTable) and emits the joined rows as chunks on out rather
+// than materializing them into a DataTable. build is hashed entirely
+// into memory; probe is streamed chunk-at-a-time. out is closed when the
+// join completes. Callers pass the left table as build and the right
+// table as probe, so that joined rows come out as [left... right...],
+// matching Join/LeftJoin/HashJoin.
+func HashJoinChunks(build, probe Table, fnBuild, fnProbe func([]string) []string, out chan<- *Chunk) error {
+	defer close(out)
+	ht, err := buildHashTable(build, fnBuild)
+	if err != nil {
+		return err
+	}
+	join := func(probeRow, buildRow []string) []string {
+		return append(append([]string{}, buildRow...), probeRow...)
+	}
+	rows := make(chan []string)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(rows)
+		errc <- probeHashTable(probe, fnProbe, ht, join, rows)
+	}()
+	batch := make([][]string, 0, defaultChunkSize)
+	for row := range rows {
+		batch = append(batch, row)
+		if len(batch) == defaultChunkSize {
+			out <- &Chunk{rows: batch}
+			batch = make([][]string, 0, defaultChunkSize)
+		}
+	}
+	if len(batch) > 0 {
+		out <- &Chunk{rows: batch}
+	}
+	return <-errc
+}
+
+// GraceHashJoin performs a hash-equal join between left and right when
+// neither side is assumed to fit comfortably in memory. It partitions
+// both sides into numPartitions spill files by hashing the join key, then
+// joins matching partitions one pair at a time with HashJoinChunks, so
+// that only a single partition of each side is held in memory at once.
+// Joined rows are emitted as chunks on out, which is closed when the
+// join completes.
+func GraceHashJoin(left, right Table, fnLeft, fnRight func([]string) []string, numPartitions int, out chan<- *Chunk) error {
+	defer close(out)
+
+	leftParts, err := partitionTable(left, fnLeft, numPartitions)
+	if err != nil {
+		return err
+	}
+	defer removePartitions(leftParts)
+
+	rightParts, err := partitionTable(right, fnRight, numPartitions)
+	if err != nil {
+		return err
+	}
+	defer removePartitions(rightParts)
+
+	for p := 0; p < numPartitions; p++ {
+		leftTable, err := leftParts[p].toStreamTable(left.NumCol())
+		if err != nil {
+			return err
+		}
+		rightTable, err := rightParts[p].toStreamTable(right.NumCol())
+		if err != nil {
+			leftTable.Close()
+			return err
+		}
+
+		sub := make(chan *Chunk)
+		done := make(chan error, 1)
+		go func() { done <- HashJoinChunks(leftTable, rightTable, fnLeft, fnRight, sub) }()
+		for chunk := range sub {
+			out <- chunk
+		}
+		leftTable.Close()
+		rightTable.Close()
+		if err := <-done; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partitionSpill is a single spill file holding the rows hashed into one
+// partition.
+type partitionSpill struct {
+	path string
+}
+
+// partitionTable streams t chunk-at-a-time and fans its rows out into
+// numPartitions spill files on disk, keyed by hash(fn(row)) % numPartitions.
+func partitionTable(t Table, fn func([]string) []string, numPartitions int) ([]*partitionSpill, error) {
+	files := make([]*os.File, numPartitions)
+	parts := make([]*partitionSpill, numPartitions)
+	for p := 0; p < numPartitions; p++ {
+		f, err := os.CreateTemp("", "datatable-partition-*")
+		if err != nil {
+			return nil, err
+		}
+		files[p] = f
+		parts[p] = &partitionSpill{path: f.Name()}
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	writers := make([]*csv.Writer, numPartitions)
+	for p, f := range files {
+		writers[p] = csv.NewWriter(f)
+	}
+
+	if err := t.Reset(); err != nil {
+		return nil, err
+	}
+	for {
+		chunk, err := t.NextChunk()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range chunk.Rows() {
+			p := partitionOf(encodeKey(fn(row)), numPartitions)
+			if err := writers[p].Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, w := range writers {
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
+
+// partitionOf hashes key into [0, numPartitions).
+func partitionOf(key string, numPartitions int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32()) % numPartitions
+}
+
+// toStreamTable opens the spill file for reading as a resettable Table.
+func (p *partitionSpill) toStreamTable(ncol int) (*StreamTable, error) {
+	return NewStreamTableFromFile(p.path, ncol, func(path string) (io.Reader, io.Closer, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, f, nil
+	})
+}
+
+func removePartitions(parts []*partitionSpill) {
+	for _, p := range parts {
+		os.Remove(p.path)
+	}
+}
@@ -0,0 +1,319 @@
+package datatable
+
+import (
+	"encoding/csv"
+	"sort"
+)
+
+// diffSampleRows is the number of leading rows inspected when
+// heuristically aligning the columns of two tables.
+const diffSampleRows = 10
+
+// diffColumnMatchThreshold is the minimum fraction of sampled rows
+// that must agree for two columns to be considered the same column.
+const diffColumnMatchThreshold = 0.8
+
+// unmappedColumn is the sentinel stored in a column mapping when a
+// column on one side has no corresponding column on the other side.
+const unmappedColumn = -1
+
+// TableDiffCellType describes how a single cell, or a whole row,
+// changed between the base and head tables.
+type TableDiffCellType int
+
+const (
+	// DiffEqual means the left and right values are identical.
+	DiffEqual TableDiffCellType = iota
+	// DiffChanged means the left and right values differ.
+	DiffChanged
+	// DiffAdd means the value only exists on the head side.
+	DiffAdd
+	// DiffDel means the value only exists on the base side.
+	DiffDel
+)
+
+// TableDiffCell is the diff result for a single column of a single row.
+type TableDiffCell struct {
+	LeftCell  string
+	RightCell string
+	Type      TableDiffCellType
+}
+
+// TableDiffRow is the diff result for a single row, aligned column-by-column
+// according to the column mapping computed for the enclosing TableDiffResult.
+type TableDiffRow struct {
+	// Type is Equal or Changed when the row is matched on both sides,
+	// Add when the row only exists in head, and Del when it only exists in base.
+	Type  TableDiffCellType
+	Cells []TableDiffCell
+}
+
+// TableDiffResult is the output of TableDiff: the rows of the two tables
+// aligned and compared, plus the column mapping used to align them.
+type TableDiffResult struct {
+	Rows []TableDiffRow
+
+	// ColMapBaseToHead maps a base column index to the head column index
+	// it was matched with, or unmappedColumn if it has no match.
+	ColMapBaseToHead map[int]int
+	// ColMapHeadToBase maps a head column index to the base column index
+	// it was matched with, or unmappedColumn if it has no match.
+	ColMapHeadToBase map[int]int
+}
+
+// outCol describes one column slot in the aligned diff output.
+// Either baseCol or headCol may be unmappedColumn, but not both.
+type outCol struct {
+	baseCol int
+	headCol int
+}
+
+// TableDiff computes a cell-level diff between base and head, whose columns
+// may have been reordered, inserted, or deleted between the two versions.
+//
+// Columns are aligned by sampling the first diffSampleRows rows of both
+// tables and matching the pair of columns that agree most often, so long as
+// they agree at least diffColumnMatchThreshold of the time. Rows are then
+// aligned using an LCS over the primary column (base column 0, and its
+// matched head column if any), so that unchanged rows on either side of an
+// insertion or deletion still line up.
+func TableDiff(base, head *DataTable) *TableDiffResult {
+	baseToHead, headToBase := alignColumns(base, head)
+	cols := diffColumnOrder(base, head, baseToHead)
+
+	primaryBase := 0
+	primaryHead := 0
+	if baseToHead[0] != unmappedColumn {
+		primaryHead = baseToHead[0]
+	}
+
+	baseKeys := primaryKeys(base, primaryBase)
+	headKeys := primaryKeys(head, primaryHead)
+
+	rows := make([]TableDiffRow, 0, base.NumRow()+head.NumRow())
+	i, j := 0, 0
+	for _, op := range diffRowOps(baseKeys, headKeys) {
+		switch op {
+		case rowOpMatch:
+			rows = append(rows, diffMatchedRow(base.GetRow(i), head.GetRow(j), cols))
+			i++
+			j++
+		case rowOpDel:
+			rows = append(rows, diffOneSidedRow(base.GetRow(i), nil, DiffDel, cols))
+			i++
+		case rowOpAdd:
+			rows = append(rows, diffOneSidedRow(nil, head.GetRow(j), DiffAdd, cols))
+			j++
+		}
+	}
+
+	return &TableDiffResult{
+		Rows:             rows,
+		ColMapBaseToHead: baseToHead,
+		ColMapHeadToBase: headToBase,
+	}
+}
+
+// DiffCSV is a convenience wrapper around TableDiff that reads the base and
+// head tables from CSV first.
+func DiffCSV(baseReader, headReader *csv.Reader) (*TableDiffResult, error) {
+	base, err := FromCSV(baseReader)
+	if err != nil {
+		return nil, err
+	}
+	head, err := FromCSV(headReader)
+	if err != nil {
+		return nil, err
+	}
+	return TableDiff(base, head), nil
+}
+
+// alignColumns greedily matches base columns to head columns by sampling
+// the leading rows of both tables and pairing whichever columns agree most
+// often, highest ratio first.
+func alignColumns(base, head *DataTable) (baseToHead, headToBase map[int]int) {
+	baseToHead = make(map[int]int, base.NumCol())
+	headToBase = make(map[int]int, head.NumCol())
+	for i := 0; i < base.NumCol(); i++ {
+		baseToHead[i] = unmappedColumn
+	}
+	for j := 0; j < head.NumCol(); j++ {
+		headToBase[j] = unmappedColumn
+	}
+
+	sampleRows := diffSampleRows
+	if base.NumRow() < sampleRows {
+		sampleRows = base.NumRow()
+	}
+	if head.NumRow() < sampleRows {
+		sampleRows = head.NumRow()
+	}
+	if sampleRows == 0 {
+		return baseToHead, headToBase
+	}
+
+	type candidate struct {
+		i, j  int
+		ratio float64
+	}
+	candidates := make([]candidate, 0, base.NumCol()*head.NumCol())
+	for i := 0; i < base.NumCol(); i++ {
+		for j := 0; j < head.NumCol(); j++ {
+			var matches int
+			for r := 0; r < sampleRows; r++ {
+				if base.Get(r, i) == head.Get(r, j) {
+					matches++
+				}
+			}
+			candidates = append(candidates, candidate{i, j, float64(matches) / float64(sampleRows)})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].ratio != candidates[b].ratio {
+			return candidates[a].ratio > candidates[b].ratio
+		}
+		if candidates[a].i != candidates[b].i {
+			return candidates[a].i < candidates[b].i
+		}
+		return candidates[a].j < candidates[b].j
+	})
+
+	for _, c := range candidates {
+		if c.ratio < diffColumnMatchThreshold {
+			break
+		}
+		if baseToHead[c.i] != unmappedColumn || headToBase[c.j] != unmappedColumn {
+			continue
+		}
+		baseToHead[c.i] = c.j
+		headToBase[c.j] = c.i
+	}
+	return baseToHead, headToBase
+}
+
+// diffColumnOrder lays out the output column slots: base columns in their
+// original order (paired with their matched head column, if any), followed
+// by any head-only columns in their original order.
+func diffColumnOrder(base, head *DataTable, baseToHead map[int]int) []outCol {
+	cols := make([]outCol, 0, base.NumCol()+head.NumCol())
+	for i := 0; i < base.NumCol(); i++ {
+		cols = append(cols, outCol{baseCol: i, headCol: baseToHead[i]})
+	}
+	matchedHead := make(map[int]bool, head.NumCol())
+	for i := 0; i < base.NumCol(); i++ {
+		if baseToHead[i] != unmappedColumn {
+			matchedHead[baseToHead[i]] = true
+		}
+	}
+	for j := 0; j < head.NumCol(); j++ {
+		if !matchedHead[j] {
+			cols = append(cols, outCol{baseCol: unmappedColumn, headCol: j})
+		}
+	}
+	return cols
+}
+
+// primaryKeys extracts the row key used for row alignment from column col
+// of every row in dt.
+func primaryKeys(dt *DataTable, col int) []string {
+	keys := make([]string, dt.NumRow())
+	for r := 0; r < dt.NumRow(); r++ {
+		keys[r] = dt.Get(r, col)
+	}
+	return keys
+}
+
+type rowOp int
+
+const (
+	rowOpMatch rowOp = iota
+	rowOpDel
+	rowOpAdd
+)
+
+// diffRowOps runs a classic LCS over baseKeys and headKeys and returns the
+// sequence of row operations (match/del/add) needed to walk both tables in
+// parallel.
+func diffRowOps(baseKeys, headKeys []string) []rowOp {
+	n, m := len(baseKeys), len(headKeys)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if baseKeys[i] == headKeys[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]rowOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		if baseKeys[i] == headKeys[j] {
+			ops = append(ops, rowOpMatch)
+			i++
+			j++
+		} else if dp[i+1][j] >= dp[i][j+1] {
+			ops = append(ops, rowOpDel)
+			i++
+		} else {
+			ops = append(ops, rowOpAdd)
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, rowOpDel)
+	}
+	for ; j < m; j++ {
+		ops = append(ops, rowOpAdd)
+	}
+	return ops
+}
+
+// diffMatchedRow builds the diff row for a base row matched with a head row.
+func diffMatchedRow(baseRow, headRow []string, cols []outCol) TableDiffRow {
+	cells := make([]TableDiffCell, len(cols))
+	rowType := DiffEqual
+	for c, col := range cols {
+		switch {
+		case col.baseCol != unmappedColumn && col.headCol != unmappedColumn:
+			l, r := baseRow[col.baseCol], headRow[col.headCol]
+			t := DiffEqual
+			if l != r {
+				t = DiffChanged
+				rowType = DiffChanged
+			}
+			cells[c] = TableDiffCell{LeftCell: l, RightCell: r, Type: t}
+		case col.baseCol != unmappedColumn:
+			cells[c] = TableDiffCell{LeftCell: baseRow[col.baseCol], Type: DiffDel}
+			rowType = DiffChanged
+		default:
+			cells[c] = TableDiffCell{RightCell: headRow[col.headCol], Type: DiffAdd}
+			rowType = DiffChanged
+		}
+	}
+	return TableDiffRow{Type: rowType, Cells: cells}
+}
+
+// diffOneSidedRow builds the diff row for a row that only exists in base
+// (rowType == DiffDel) or only exists in head (rowType == DiffAdd).
+func diffOneSidedRow(baseRow, headRow []string, rowType TableDiffCellType, cols []outCol) TableDiffRow {
+	cells := make([]TableDiffCell, len(cols))
+	for c, col := range cols {
+		switch {
+		case baseRow != nil && col.baseCol != unmappedColumn:
+			cells[c] = TableDiffCell{LeftCell: baseRow[col.baseCol], Type: rowType}
+		case headRow != nil && col.headCol != unmappedColumn:
+			cells[c] = TableDiffCell{RightCell: headRow[col.headCol], Type: rowType}
+		default:
+			cells[c] = TableDiffCell{Type: rowType}
+		}
+	}
+	return TableDiffRow{Type: rowType, Cells: cells}
+}
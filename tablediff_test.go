@@ -0,0 +1,116 @@
+package datatable
+
+import "testing"
+
+func Test_TableDiff_ColumnAlignment(t *testing.T) {
+	base := NewDataTable(3)
+	base.AppendRow([]string{"id", "name", "age"})
+	base.AppendRow([]string{"1", "alice", "30"})
+	base.AppendRow([]string{"2", "bob", "25"})
+	base.AppendRow([]string{"3", "carol", "40"})
+
+	// head has the first two columns reordered, but the same rows.
+	head := NewDataTable(3)
+	head.AppendRow([]string{"name", "id", "age"})
+	head.AppendRow([]string{"alice", "1", "30"})
+	head.AppendRow([]string{"bob", "2", "25"})
+	head.AppendRow([]string{"carol", "3", "40"})
+
+	result := TableDiff(base, head)
+
+	if got := result.ColMapBaseToHead[0]; got != 1 {
+		t.Errorf("expected base column 0 (id) to map to head column 1, got %d", got)
+	}
+	if got := result.ColMapBaseToHead[1]; got != 0 {
+		t.Errorf("expected base column 1 (name) to map to head column 0, got %d", got)
+	}
+	if got := result.ColMapBaseToHead[2]; got != 2 {
+		t.Errorf("expected base column 2 (age) to map to head column 2, got %d", got)
+	}
+	if got := result.ColMapHeadToBase[0]; got != 1 {
+		t.Errorf("expected head column 0 (name) to map to base column 1, got %d", got)
+	}
+
+	for _, row := range result.Rows {
+		if row.Type != DiffEqual {
+			t.Errorf("expected all rows to be Equal once columns are aligned, got %v", row.Type)
+		}
+	}
+}
+
+func Test_TableDiff_UnmatchedBasePrimaryColumn(t *testing.T) {
+	// base has an extra leading column that head has no match for, and
+	// head's matched "id" column sits at a base column index (2) that
+	// is out of range for head (which only has 2 columns). Computing
+	// head's primary-key column must not use a base column index as a
+	// head column index, or this panics with index out of range.
+	base := NewDataTable(3)
+	head := NewDataTable(2)
+	for i := 0; i < diffSampleRows; i++ {
+		n := string(rune('0' + i))
+		base.AppendRow([]string{"extra" + n, "name" + n, n})
+		head.AppendRow([]string{n, "name" + n})
+	}
+
+	// TableDiff must not panic: the old code seeded head's primary-key
+	// column from headToBase[0] (a base column index), which is out of
+	// range here since head has fewer columns than base.
+	result := TableDiff(base, head)
+
+	if got := result.ColMapBaseToHead[0]; got != unmappedColumn {
+		t.Errorf("expected base column 0 (extra) to be unmapped, got %d", got)
+	}
+	if got := result.ColMapBaseToHead[2]; got != 0 {
+		t.Errorf("expected base column 2 (id) to map to head column 0, got %d", got)
+	}
+	// Row alignment uses base column 0 as the primary key, which here is
+	// the unmatched "extra" column, so every base/head row is reported
+	// as a pure Del/Add rather than matched -- that's an existing
+	// limitation of using a fixed primary column, not something this
+	// test is asserting is ideal behavior.
+	if len(result.Rows) != base.NumRow()+head.NumRow() {
+		t.Fatalf("expected %d diff rows, got %d", base.NumRow()+head.NumRow(), len(result.Rows))
+	}
+}
+
+func Test_TableDiff_RowChanges(t *testing.T) {
+	base := NewDataTable(2)
+	head := NewDataTable(2)
+
+	// A stable common prefix of diffSampleRows rows so the column
+	// alignment heuristic has unambiguous data to work with, regardless
+	// of the row changes that follow it.
+	for i := 0; i < diffSampleRows; i++ {
+		id := string(rune('0' + i))
+		base.AppendRow([]string{id, "user" + id})
+		head.AppendRow([]string{id, "user" + id})
+	}
+
+	base.AppendRow([]string{"a", "alice"})
+	base.AppendRow([]string{"b", "bob"})
+	base.AppendRow([]string{"c", "carol"})
+
+	// bob is deleted, alice's name is changed, and dave is added.
+	head.AppendRow([]string{"a", "alicia"})
+	head.AppendRow([]string{"c", "carol"})
+	head.AppendRow([]string{"d", "dave"})
+
+	result := TableDiff(base, head)
+
+	if len(result.Rows) != diffSampleRows+4 {
+		t.Fatalf("expected %d diff rows, got %d", diffSampleRows+4, len(result.Rows))
+	}
+	tail := result.Rows[diffSampleRows:]
+	if tail[0].Type != DiffChanged {
+		t.Errorf("expected row for id=a (alice/alicia) to be Changed, got %v", tail[0].Type)
+	}
+	if tail[1].Type != DiffDel {
+		t.Errorf("expected row for id=b (bob) to be Del, got %v", tail[1].Type)
+	}
+	if tail[2].Type != DiffEqual {
+		t.Errorf("expected row for id=c (carol) to be Equal, got %v", tail[2].Type)
+	}
+	if tail[3].Type != DiffAdd {
+		t.Errorf("expected row for id=d (dave) to be Add, got %v", tail[3].Type)
+	}
+}